@@ -0,0 +1,34 @@
+package vsphere
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/vmware/govmomi"
+	"golang.org/x/net/context"
+)
+
+// Config holds the provider-level settings needed to authenticate against
+// a vSphere endpoint and build a govmomi client.
+type Config struct {
+	User          string
+	Password      string
+	VSphereServer string
+	InsecureFlag  bool
+}
+
+// Client builds a govmomi.Client from the Config.
+func (c *Config) Client() (*govmomi.Client, error) {
+	u, err := url.Parse(fmt.Sprintf("https://%s/sdk", c.VSphereServer))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing url: %s", err)
+	}
+	u.User = url.UserPassword(c.User, c.Password)
+
+	client, err := govmomi.NewClient(context.Background(), u, c.InsecureFlag)
+	if err != nil {
+		return nil, fmt.Errorf("error setting up client: %s", err)
+	}
+
+	return client, nil
+}
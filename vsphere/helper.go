@@ -0,0 +1,28 @@
+package vsphere
+
+import (
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"golang.org/x/net/context"
+)
+
+// getDatacenter gets datacenter object
+func getDatacenter(c *govmomi.Client, dc string) (*object.Datacenter, error) {
+	finder := find.NewFinder(c.Client, true)
+	if dc != "" {
+		d, err := finder.Datacenter(context.TODO(), dc)
+		return d, err
+	} else {
+		d, err := finder.DefaultDatacenter(context.TODO())
+		return d, err
+	}
+}
+
+// getDatastore gets datastore object
+func getDatastore(f *find.Finder, ds string) (*object.Datastore, error) {
+	if ds != "" {
+		return f.Datastore(context.TODO(), ds)
+	}
+	return f.DefaultDatastore(context.TODO())
+}
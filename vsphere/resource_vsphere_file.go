@@ -0,0 +1,295 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+	"path"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"golang.org/x/net/context"
+)
+
+type vsphereFile struct {
+	datacenter        string
+	datastore         string
+	sourceDatacenter  string
+	sourceDatastore   string
+	sourceFile        string
+	destinationFile   string
+	createDirectories bool
+	move              bool
+	overwrite         bool
+}
+
+func resourceVSphereFile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereFileCreate,
+		Read:   resourceVSphereFileRead,
+		Update: resourceVSphereFileUpdate,
+		Delete: resourceVSphereFileDelete,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"datastore": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"source_datacenter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"source_datastore": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"source_file": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"destination_file": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"create_directories": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"move": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"overwrite": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceVSphereFileCreate(d *schema.ResourceData, meta interface{}) error {
+
+	client := meta.(*govmomi.Client)
+
+	f := vsphereFile{
+		datastore:         d.Get("datastore").(string),
+		sourceFile:        d.Get("source_file").(string),
+		destinationFile:   d.Get("destination_file").(string),
+		createDirectories: d.Get("create_directories").(bool),
+		move:              d.Get("move").(bool),
+		overwrite:         d.Get("overwrite").(bool),
+	}
+
+	if v, ok := d.GetOk("datacenter"); ok {
+		f.datacenter = v.(string)
+	}
+	if v, ok := d.GetOk("source_datacenter"); ok {
+		f.sourceDatacenter = v.(string)
+	}
+	if v, ok := d.GetOk("source_datastore"); ok {
+		f.sourceDatastore = v.(string)
+	}
+
+	err := createFile(client, &f)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%v/%v", f.datastore, f.destinationFile))
+	log.Printf("[INFO] Created file: %s", f.destinationFile)
+
+	return resourceVSphereFileRead(d, meta)
+}
+
+func createFile(client *govmomi.Client, f *vsphereFile) error {
+	dc, err := getDatacenter(client, f.datacenter)
+	if err != nil {
+		return err
+	}
+
+	finder := find.NewFinder(client.Client, true)
+	finder = finder.SetDatacenter(dc)
+
+	ds, err := getDatastore(finder, f.datastore)
+	if err != nil {
+		return fmt.Errorf("error %s", err)
+	}
+
+	if f.createDirectories {
+		fm := object.NewFileManager(client.Client)
+		dir, _ := path.Split(f.destinationFile)
+		if dir != "" {
+			if err := fm.MakeDirectory(context.TODO(), ds.Path(dir), dc, true); err != nil {
+				return fmt.Errorf("error creating parent directories: %s", err)
+			}
+		}
+	}
+
+	if f.sourceDatastore != "" {
+		srcDc := dc
+		if f.sourceDatacenter != "" {
+			srcDc, err = getDatacenter(client, f.sourceDatacenter)
+			if err != nil {
+				return err
+			}
+		}
+
+		srcFinder := find.NewFinder(client.Client, true)
+		srcFinder = srcFinder.SetDatacenter(srcDc)
+		srcDs, err := getDatastore(srcFinder, f.sourceDatastore)
+		if err != nil {
+			return fmt.Errorf("error %s", err)
+		}
+
+		fm := object.NewFileManager(client.Client)
+		var task *object.Task
+		if f.move {
+			task, err = fm.MoveDatastoreFile(context.TODO(), srcDs.Path(f.sourceFile), srcDc, ds.Path(f.destinationFile), dc, f.overwrite)
+		} else {
+			task, err = fm.CopyDatastoreFile(context.TODO(), srcDs.Path(f.sourceFile), srcDc, ds.Path(f.destinationFile), dc, f.overwrite)
+		}
+		if err != nil {
+			return fmt.Errorf("error copying file: %s", err)
+		}
+		return task.Wait(context.TODO())
+	}
+
+	return ds.UploadFile(context.TODO(), f.sourceFile, f.destinationFile, nil)
+}
+
+func resourceVSphereFileRead(d *schema.ResourceData, meta interface{}) error {
+
+	log.Printf("[DEBUG] reading file: %#v", d)
+	client := meta.(*govmomi.Client)
+
+	dc, err := getDatacenter(client, d.Get("datacenter").(string))
+	if err != nil {
+		return err
+	}
+
+	finder := find.NewFinder(client.Client, true)
+	finder = finder.SetDatacenter(dc)
+
+	ds, err := getDatastore(finder, d.Get("datastore").(string))
+	if err != nil {
+		return fmt.Errorf("error %s", err)
+	}
+
+	_, err = ds.Stat(context.TODO(), d.Get("destination_file").(string))
+	if err != nil {
+		if _, ok := err.(object.DatastoreNoSuchFileError); ok {
+			log.Printf("[DEBUG] file %s no longer exists", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func resourceVSphereFileUpdate(d *schema.ResourceData, meta interface{}) error {
+
+	client := meta.(*govmomi.Client)
+
+	if !d.HasChange("destination_file") {
+		return resourceVSphereFileRead(d, meta)
+	}
+
+	dc, err := getDatacenter(client, d.Get("datacenter").(string))
+	if err != nil {
+		return err
+	}
+
+	finder := find.NewFinder(client.Client, true)
+	finder = finder.SetDatacenter(dc)
+
+	ds, err := getDatastore(finder, d.Get("datastore").(string))
+	if err != nil {
+		return fmt.Errorf("error %s", err)
+	}
+
+	old, new := d.GetChange("destination_file")
+
+	fm := object.NewFileManager(client.Client)
+	task, err := fm.MoveDatastoreFile(context.TODO(), ds.Path(old.(string)), dc, ds.Path(new.(string)), dc, d.Get("overwrite").(bool))
+	if err != nil {
+		return fmt.Errorf("error renaming file: %s", err)
+	}
+	if err := task.Wait(context.TODO()); err != nil {
+		return fmt.Errorf("error renaming file: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%v/%v", d.Get("datastore").(string), new.(string)))
+
+	return resourceVSphereFileRead(d, meta)
+}
+
+func resourceVSphereFileDelete(d *schema.ResourceData, meta interface{}) error {
+
+	client := meta.(*govmomi.Client)
+
+	f := vsphereFile{
+		datastore:       d.Get("datastore").(string),
+		destinationFile: d.Get("destination_file").(string),
+	}
+
+	if v, ok := d.GetOk("datacenter"); ok {
+		f.datacenter = v.(string)
+	}
+
+	err := deleteFile(client, &f)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func deleteFile(client *govmomi.Client, f *vsphereFile) error {
+	dc, err := getDatacenter(client, f.datacenter)
+	if err != nil {
+		return err
+	}
+
+	finder := find.NewFinder(client.Client, true)
+	finder = finder.SetDatacenter(dc)
+
+	ds, err := getDatastore(finder, f.datastore)
+	if err != nil {
+		return fmt.Errorf("error %s", err)
+	}
+
+	fm := object.NewFileManager(client.Client)
+	task, err := fm.DeleteDatastoreFile(context.TODO(), ds.Path(f.destinationFile), dc)
+	if err != nil {
+		return fmt.Errorf("error deleting file: %s", err)
+	}
+
+	return task.Wait(context.TODO())
+}
@@ -11,6 +11,10 @@ import (
 	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
 	"golang.org/x/net/context"
 )
 
@@ -18,14 +22,24 @@ type folder struct {
 	datacenter   string
 	existingPath string
 	path         string
+	kind         string
+	force        bool
 }
 
+// folderTypes enumerates the inventory subtrees a vsphere_folder can be
+// created under.
+var folderTypes = []string{"vm", "host", "datastore", "network", "datacenter"}
+
 func resourceVSphereFolder() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceVSphereFolderCreate,
 		Read:   resourceVSphereFolderRead,
 		Delete: resourceVSphereFolderDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceVSphereFolderImport,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"datacenter": &schema.Schema{
 				Type:     schema.TypeString,
@@ -39,49 +53,78 @@ func resourceVSphereFolder() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateFolderType,
+			},
+
 			"existing_path": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"force": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
+func validateFolderType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	for _, t := range folderTypes {
+		if value == t {
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf("%q must be one of %v, got %q", k, folderTypes, value))
+	return
+}
+
 func resourceVSphereFolderCreate(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*govmomi.Client)
 
 	f := folder{
 		path: strings.TrimRight(d.Get("path").(string), "/"),
+		kind: d.Get("type").(string),
 	}
 
 	if v, ok := d.GetOk("datacenter"); ok {
 		f.datacenter = v.(string)
 	}
 
-	err := createFolder(client, &f)
+	created, err := createFolder(client, &f)
 	if err != nil {
 		return err
 	}
 
 	d.Set("existing_path", f.existingPath)
-	d.SetId(fmt.Sprintf("%v/%v", f.datacenter, f.path))
+	d.SetId(created.Reference().Value)
 	log.Printf("[INFO] Created folder: %s", f.path)
 
 	return resourceVSphereFolderRead(d, meta)
 }
 
-func createFolder(client *govmomi.Client, f *folder) error {
+func createFolder(client *govmomi.Client, f *folder) (*object.Folder, error) {
 
 	finder := find.NewFinder(client.Client, false)
 
 	dc, err := finder.DatacenterOrDefault(context.Background(), f.datacenter)
 	if err != nil {
-		return fmt.Errorf("error %s", err)
+		return nil, fmt.Errorf("error %s", err)
 	}
 	finder = finder.SetDatacenter(dc)
 	si := object.NewSearchIndex(client.Client)
-	base := filepath.Join(dc.InventoryPath, "vm")
+
+	base, err := folderRootPath(dc, f.kind)
+	if err != nil {
+		return nil, err
+	}
 	path := filepath.ToSlash(filepath.Join(base, f.path))
 
 	var folders []string
@@ -91,17 +134,30 @@ func createFolder(client *govmomi.Client, f *folder) error {
 	// If we don't find it, we save the folder name and continue with the previous path
 	// The iteration ends when we find an existing path otherwise it throws error
 	for {
-		ref, err = si.FindByInventoryPath(context.Background(), path)
-		if err != nil {
-			return fmt.Errorf("error %s", err)
+		if path == base && f.kind == "datacenter" {
+			// The global root folder isn't resolvable through
+			// SearchIndex.FindByInventoryPath (it has no addressable path
+			// of its own), so resolve it directly instead of searching.
+			ref = object.NewRootFolder(client.Client)
+		} else {
+			ref, err = si.FindByInventoryPath(context.Background(), path)
+			if err != nil {
+				return nil, fmt.Errorf("error %s", err)
+			}
 		}
 		if ref == nil {
 			_, folder := filepath.Split(path)
 			folders = append(folders, folder)
 			path = path[:strings.LastIndex(path, "/")]
+			if path == "" {
+				// Stripping the last segment off a top-level path (e.g.
+				// "/NewCustomFolder") leaves "", which means "the root
+				// folder" rather than "below the root".
+				path = "/"
+			}
 
-			if path == dc.InventoryPath {
-				return fmt.Errorf("vSphere base path %s not found", filepath.ToSlash(base))
+			if len(path) < len(base) {
+				return nil, fmt.Errorf("vSphere base path %s not found", filepath.ToSlash(base))
 			}
 		} else {
 			break
@@ -111,12 +167,46 @@ func createFolder(client *govmomi.Client, f *folder) error {
 	root := ref.(*object.Folder)
 	for i := len(folders) - 1; i >= 0; i-- {
 		log.Printf("[DEBUG] folder not found; creating: %s", folders[i])
-		root, err = root.CreateFolder(context.Background(), folders[i])
+		child, err := root.CreateFolder(context.Background(), folders[i])
 		if err != nil {
-			return fmt.Errorf("Failed to create folder at %s; %s", root.InventoryPath, err)
+			if !isDuplicateNameFault(err) {
+				return nil, fmt.Errorf("Failed to create folder at %s; %s", root.InventoryPath, err)
+			}
+
+			// Someone else (a concurrent apply) created the folder between our
+			// lookup and our create call; resolve it instead of failing.
+			log.Printf("[DEBUG] folder %s already exists; re-resolving", folders[i])
+			childRef, ferr := si.FindChild(context.Background(), root, folders[i])
+			if ferr != nil || childRef == nil {
+				return nil, fmt.Errorf("Failed to create folder at %s; %s", root.InventoryPath, err)
+			}
+			child = childRef.(*object.Folder)
 		}
+		root = child
 	}
-	return nil
+	return root, nil
+}
+
+// isDuplicateNameFault returns true if err is a vSphere DuplicateName fault,
+// as returned when another client creates a folder with the same name
+// concurrently.
+func isDuplicateNameFault(err error) bool {
+	if !soap.IsSoapFault(err) {
+		return false
+	}
+	_, ok := soap.ToSoapFault(err).VimFault().(types.DuplicateName)
+	return ok
+}
+
+// isManagedObjectNotFoundFault returns true if err is a vSphere
+// ManagedObjectNotFound fault, as returned when another client has already
+// deleted the object in question.
+func isManagedObjectNotFoundFault(err error) bool {
+	if !soap.IsSoapFault(err) {
+		return false
+	}
+	_, ok := soap.ToSoapFault(err).VimFault().(types.ManagedObjectNotFound)
+	return ok
 }
 
 func resourceVSphereFolderRead(d *schema.ResourceData, meta interface{}) error {
@@ -124,6 +214,25 @@ func resourceVSphereFolderRead(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[DEBUG] reading folder: %#v", d)
 	client := meta.(*govmomi.Client)
 
+	if !strings.Contains(d.Id(), "/") {
+		// Canonical MOID-based id: look the folder up directly by reference
+		// so that renaming a parent in vCenter doesn't orphan the resource.
+		ref := types.ManagedObjectReference{Type: "Folder", Value: d.Id()}
+		var mf mo.Folder
+		err := property.DefaultCollector(client.Client).RetrieveOne(context.TODO(), ref, []string{"name"}, &mf)
+		if err != nil {
+			if isManagedObjectNotFoundFault(err) {
+				d.SetId("")
+				return nil
+			}
+			return err
+		}
+		return nil
+	}
+
+	// Legacy "<datacenter>/<path>" id from before MOID-based ids were
+	// introduced; fall back to an inventory-path lookup and upgrade state
+	// to the canonical MOID once resolved.
 	dc, err := getDatacenter(client, d.Get("datacenter").(string))
 	if err != nil {
 		return err
@@ -132,26 +241,71 @@ func resourceVSphereFolderRead(d *schema.ResourceData, meta interface{}) error {
 	finder := find.NewFinder(client.Client, true)
 	finder = finder.SetDatacenter(dc)
 
-	folder, err := object.NewSearchIndex(client.Client).FindByInventoryPath(
-		context.TODO(), fmt.Sprintf("%v/vm/%v", d.Get("datacenter").(string),
-			d.Get("path").(string)))
+	kind := d.Get("type").(string)
+	if kind == "" {
+		// Resources created before the "type" field existed have no type
+		// in their saved state; they were always "vm" folders.
+		kind = "vm"
+	}
+
+	base, err := folderRootPath(dc, kind)
+	if err != nil {
+		return err
+	}
+
+	ref, err := object.NewSearchIndex(client.Client).FindByInventoryPath(
+		context.TODO(), filepath.ToSlash(filepath.Join(base, d.Get("path").(string))))
 
 	if err != nil {
 		return err
 	}
 
-	if folder == nil {
+	if ref == nil {
 		d.SetId("")
+		return nil
 	}
 
+	d.SetId(ref.(*object.Folder).Reference().Value)
+
 	return nil
 }
 
+// folderRootPath returns the inventory path of the root folder that
+// folders of the given type are created under. For "datacenter" typed
+// folders, the root is the inventory's global root folder (used to
+// organize Datacenter objects themselves), independent of dc; vSphere
+// doesn't allow folders as direct children of a Datacenter.
+func folderRootPath(dc *object.Datacenter, kind string) (string, error) {
+	if kind == "datacenter" {
+		return "/", nil
+	}
+
+	folders, err := dc.Folders(context.TODO())
+	if err != nil {
+		return "", fmt.Errorf("error %s", err)
+	}
+
+	switch kind {
+	case "vm":
+		return folders.VmFolder.InventoryPath, nil
+	case "host":
+		return folders.HostFolder.InventoryPath, nil
+	case "datastore":
+		return folders.DatastoreFolder.InventoryPath, nil
+	case "network":
+		return folders.NetworkFolder.InventoryPath, nil
+	default:
+		return "", fmt.Errorf("unsupported folder type: %s", kind)
+	}
+}
+
 func resourceVSphereFolderDelete(d *schema.ResourceData, meta interface{}) error {
 
 	f := folder{
 		path:         strings.TrimRight(d.Get("path").(string), "/"),
 		existingPath: d.Get("existing_path").(string),
+		kind:         d.Get("type").(string),
+		force:        d.Get("force").(bool),
 	}
 
 	if v, ok := d.GetOk("datacenter"); ok {
@@ -181,11 +335,19 @@ func deleteFolder(client *govmomi.Client, f *folder) error {
 	finder = finder.SetDatacenter(dc)
 	si := object.NewSearchIndex(client.Client)
 
+	base, err := folderRootPath(dc, f.kind)
+	if err != nil {
+		return err
+	}
+
 	folderRef, err := si.FindByInventoryPath(
-		context.TODO(), fmt.Sprintf("%v/vm/%v", f.datacenter, f.path))
+		context.TODO(), filepath.ToSlash(filepath.Join(base, f.path)))
 
 	if err != nil {
 		return fmt.Errorf("[ERROR] Could not locate folder %s: %v", f.path, err)
+	} else if folderRef == nil {
+		// Already gone; nothing left to do.
+		return nil
 	} else {
 		folder = folderRef.(*object.Folder)
 	}
@@ -198,44 +360,148 @@ func deleteFolder(client *govmomi.Client, f *folder) error {
 			return err
 		}
 
-		if len(children) > 0 {
+		// Only the leaf folder (the one this resource manages) may be
+		// force-destroyed while non-empty; ancestors are shared with
+		// whatever else put children in them, so they still require
+		// emptiness like before.
+		if len(children) > 0 && !(f.force && currentPath == f.path) {
 			return fmt.Errorf("Folder %s is non-empty and will not be deleted", currentPath)
-		} else {
-			log.Printf("[DEBUG] current folder: %#v", folder)
-			currentPath = path.Dir(currentPath)
-			if currentPath == "." {
-				currentPath = ""
-			}
-			log.Printf("[INFO] parent path of %s is calculated as %s", f.path, currentPath)
-			task, err := folder.Destroy(context.TODO())
-			if err != nil {
-				return err
-			}
-			err = task.Wait(context.TODO())
-			if err != nil {
-				return err
-			}
-			folderRef, err = si.FindByInventoryPath(
-				context.TODO(), fmt.Sprintf("%v/vm/%v", f.datacenter, currentPath))
+		}
+		if len(children) > 0 {
+			log.Printf("[INFO] force destroying non-empty folder: %s", currentPath)
+		}
 
-			if err != nil {
-				return err
-			} else if folderRef != nil {
-				folder = folderRef.(*object.Folder)
-			}
+		log.Printf("[DEBUG] current folder: %#v", folder)
+		currentPath = path.Dir(currentPath)
+		if currentPath == "." {
+			currentPath = ""
+		}
+		log.Printf("[INFO] parent path of %s is calculated as %s", f.path, currentPath)
+		if err := destroyFolder(folder); err != nil {
+			return err
+		}
+		folderRef, err = si.FindByInventoryPath(
+			context.TODO(), filepath.ToSlash(filepath.Join(base, currentPath)))
+
+		if err != nil {
+			return err
+		} else if folderRef != nil {
+			folder = folderRef.(*object.Folder)
 		}
 	}
 	return nil
 }
 
-// getDatacenter gets datacenter object
-func getDatacenter(c *govmomi.Client, dc string) (*object.Datacenter, error) {
-	finder := find.NewFinder(c.Client, true)
-	if dc != "" {
-		d, err := finder.Datacenter(context.TODO(), dc)
-		return d, err
+// resourceVSphereFolderImport supports importing a vsphere_folder either by
+// its inventory path (e.g. "DC1/vm/prod/web") or by its bare managed object
+// ID (e.g. "group-v1234"). Both forms resolve to the same canonical
+// MOID-based id.
+func resourceVSphereFolderImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*govmomi.Client)
+
+	var folderObj *object.Folder
+
+	if strings.Contains(d.Id(), "/") {
+		ref, err := object.NewSearchIndex(client.Client).FindByInventoryPath(context.TODO(), d.Id())
+		if err != nil {
+			return nil, err
+		}
+		if ref == nil {
+			return nil, fmt.Errorf("no folder found at inventory path %q", d.Id())
+		}
+		folderObj = ref.(*object.Folder)
 	} else {
-		d, err := finder.DefaultDatacenter(context.TODO())
-		return d, err
+		folderObj = object.NewFolder(client.Client, types.ManagedObjectReference{Type: "Folder", Value: d.Id()})
+	}
+
+	dcName, kind, relPath, err := resolveFolderAttributes(client, folderObj)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving imported folder %s: %s", d.Id(), err)
 	}
+
+	d.Set("datacenter", dcName)
+	d.Set("type", kind)
+	d.Set("path", relPath)
+	// existing_path is left unset here, same as a normal create leaves it
+	// (createFolder never fills it in, so it is always empty). Setting it
+	// to relPath would make deleteFolder's walk-up loop
+	// (which stops at existingPath) a no-op and leak the folder on destroy.
+	d.SetId(folderObj.Reference().Value)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resolveFolderAttributes walks the folder's parent chain up to its
+// datacenter (or, for "datacenter" typed folders, up to the global root
+// folder), reconstructing the datacenter name, folder type (vm, host,
+// datastore, network, or datacenter), and the relative path under that
+// type's root, so an imported folder can populate the same state an
+// ordinary create would have. The datacenter name is empty for
+// "datacenter" typed folders.
+func resolveFolderAttributes(client *govmomi.Client, folderObj *object.Folder) (dcName, kind, relPath string, err error) {
+	pc := property.DefaultCollector(client.Client)
+	ctx := context.TODO()
+
+	var segments []string
+	ref := folderObj.Reference()
+
+	for {
+		var f mo.Folder
+		if err := pc.RetrieveOne(ctx, ref, []string{"name", "parent"}, &f); err != nil {
+			return "", "", "", err
+		}
+
+		if f.Parent == nil {
+			// Reached the inventory's global root folder without passing
+			// through a datacenter: this is a "datacenter" typed folder.
+			return "", "datacenter", strings.Join(segments, "/"), nil
+		}
+
+		parent := *f.Parent
+		if parent.Type != "Datacenter" {
+			segments = append([]string{f.Name}, segments...)
+			ref = parent
+			continue
+		}
+
+		var dc mo.Datacenter
+		if err := pc.RetrieveOne(ctx, parent, []string{"name", "vmFolder", "hostFolder", "datastoreFolder", "networkFolder"}, &dc); err != nil {
+			return "", "", "", err
+		}
+		dcName = dc.Name
+
+		switch ref.Value {
+		case dc.VmFolder.Value:
+			kind = "vm"
+		case dc.HostFolder.Value:
+			kind = "host"
+		case dc.DatastoreFolder.Value:
+			kind = "datastore"
+		case dc.NetworkFolder.Value:
+			kind = "network"
+		default:
+			return "", "", "", fmt.Errorf("folder %s is not under a known root folder of datacenter %s", ref.Value, dcName)
+		}
+
+		return dcName, kind, strings.Join(segments, "/"), nil
+	}
+}
+
+// destroyFolder destroys folder, recursively destroying any children. A
+// ManagedObjectNotFound fault is treated as success, since it means a
+// concurrent apply already deleted the folder.
+func destroyFolder(folder *object.Folder) error {
+	task, err := folder.Destroy(context.TODO())
+	if err != nil {
+		if isManagedObjectNotFoundFault(err) {
+			return nil
+		}
+		return err
+	}
+
+	err = task.Wait(context.TODO())
+	if err != nil && !isManagedObjectNotFoundFault(err) {
+		return err
+	}
+	return nil
 }
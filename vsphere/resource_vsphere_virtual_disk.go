@@ -0,0 +1,244 @@
+package vsphere
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	"golang.org/x/net/context"
+)
+
+type virtualDisk struct {
+	size        int
+	vmdkPath    string
+	datacenter  string
+	datastore   string
+	diskType    string
+	adapterType string
+}
+
+// virtualDiskTypes enumerates the disk backing types a vsphere_virtual_disk
+// can be created as.
+var virtualDiskTypes = []string{"thin", "eagerZeroedThick", "lazy"}
+
+// virtualDiskAdapterTypes enumerates the virtual disk adapter types a
+// vsphere_virtual_disk can be created as.
+var virtualDiskAdapterTypes = []string{"ide", "busLogic", "lsiLogic"}
+
+func validateVirtualDiskType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	for _, t := range virtualDiskTypes {
+		if value == t {
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf("%q must be one of %v, got %q", k, virtualDiskTypes, value))
+	return
+}
+
+func validateVirtualDiskAdapterType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	for _, t := range virtualDiskAdapterTypes {
+		if value == t {
+			return
+		}
+	}
+	errors = append(errors, fmt.Errorf("%q must be one of %v, got %q", k, virtualDiskAdapterTypes, value))
+	return
+}
+
+func resourceVSphereVirtualDisk() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVSphereVirtualDiskCreate,
+		Read:   resourceVSphereVirtualDiskRead,
+		Delete: resourceVSphereVirtualDiskDelete,
+
+		Schema: map[string]*schema.Schema{
+			"vmdk_path": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"datacenter": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"datastore": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "eagerZeroedThick",
+				ValidateFunc: validateVirtualDiskType,
+			},
+
+			"adapter_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "lsiLogic",
+				ValidateFunc: validateVirtualDiskAdapterType,
+			},
+		},
+	}
+}
+
+func resourceVSphereVirtualDiskCreate(d *schema.ResourceData, meta interface{}) error {
+
+	client := meta.(*govmomi.Client)
+
+	vd := virtualDisk{
+		size:        d.Get("size").(int),
+		vmdkPath:    d.Get("vmdk_path").(string),
+		datastore:   d.Get("datastore").(string),
+		diskType:    d.Get("type").(string),
+		adapterType: d.Get("adapter_type").(string),
+	}
+
+	if v, ok := d.GetOk("datacenter"); ok {
+		vd.datacenter = v.(string)
+	}
+
+	err := createVirtualDisk(client, &vd)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%v/%v", vd.datastore, vd.vmdkPath))
+	log.Printf("[INFO] Created virtual disk: %s", vd.vmdkPath)
+
+	return resourceVSphereVirtualDiskRead(d, meta)
+}
+
+func createVirtualDisk(client *govmomi.Client, vd *virtualDisk) error {
+	dc, err := getDatacenter(client, vd.datacenter)
+	if err != nil {
+		return err
+	}
+
+	finder := find.NewFinder(client.Client, true)
+	finder = finder.SetDatacenter(dc)
+
+	ds, err := getDatastore(finder, vd.datastore)
+	if err != nil {
+		return fmt.Errorf("error %s", err)
+	}
+
+	manager := object.NewVirtualDiskManager(client.Client)
+	spec := &types.FileBackedVirtualDiskSpec{
+		VirtualDiskSpec: types.VirtualDiskSpec{
+			AdapterType: vd.adapterType,
+			DiskType:    vd.diskType,
+		},
+		CapacityKb: int64(vd.size) * 1024 * 1024,
+	}
+
+	task, err := manager.CreateVirtualDisk(context.TODO(), ds.Path(vd.vmdkPath), dc, spec)
+	if err != nil {
+		return fmt.Errorf("error creating virtual disk: %s", err)
+	}
+
+	if _, err = task.WaitForResult(context.TODO(), nil); err != nil {
+		return fmt.Errorf("error creating virtual disk: %s", err)
+	}
+
+	return nil
+}
+
+func resourceVSphereVirtualDiskRead(d *schema.ResourceData, meta interface{}) error {
+
+	log.Printf("[DEBUG] reading virtual disk: %#v", d)
+	client := meta.(*govmomi.Client)
+
+	dc, err := getDatacenter(client, d.Get("datacenter").(string))
+	if err != nil {
+		return err
+	}
+
+	finder := find.NewFinder(client.Client, true)
+	finder = finder.SetDatacenter(dc)
+
+	ds, err := getDatastore(finder, d.Get("datastore").(string))
+	if err != nil {
+		return fmt.Errorf("error %s", err)
+	}
+
+	_, err = ds.Stat(context.TODO(), d.Get("vmdk_path").(string))
+	if err != nil {
+		if _, ok := err.(object.DatastoreNoSuchFileError); ok {
+			log.Printf("[DEBUG] virtual disk %s no longer exists", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func resourceVSphereVirtualDiskDelete(d *schema.ResourceData, meta interface{}) error {
+
+	client := meta.(*govmomi.Client)
+
+	vd := virtualDisk{
+		vmdkPath:  d.Get("vmdk_path").(string),
+		datastore: d.Get("datastore").(string),
+	}
+
+	if v, ok := d.GetOk("datacenter"); ok {
+		vd.datacenter = v.(string)
+	}
+
+	err := deleteVirtualDisk(client, &vd)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func deleteVirtualDisk(client *govmomi.Client, vd *virtualDisk) error {
+	dc, err := getDatacenter(client, vd.datacenter)
+	if err != nil {
+		return err
+	}
+
+	finder := find.NewFinder(client.Client, true)
+	finder = finder.SetDatacenter(dc)
+
+	ds, err := getDatastore(finder, vd.datastore)
+	if err != nil {
+		return fmt.Errorf("error %s", err)
+	}
+
+	manager := object.NewVirtualDiskManager(client.Client)
+	task, err := manager.DeleteVirtualDisk(context.TODO(), ds.Path(vd.vmdkPath), dc)
+	if err != nil {
+		return fmt.Errorf("error deleting virtual disk: %s", err)
+	}
+
+	if _, err = task.WaitForResult(context.TODO(), nil); err != nil {
+		return fmt.Errorf("error deleting virtual disk: %s", err)
+	}
+
+	return nil
+}